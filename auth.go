@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RegisterRequest is the body expected by POST /auth/register.
+type RegisterRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Age      int    `json:"age"`
+}
+
+// LoginRequest is the body expected by POST /auth/login.
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// tokenClaims are the custom JWT claims issued on login.
+type tokenClaims struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// tokenBlocklist tracks revoked token IDs (JTIs) so logout can invalidate a
+// token before it naturally expires. It's pluggable so a distributed backend
+// (e.g. Redis) can replace it without touching the handlers.
+type tokenBlocklist interface {
+	Revoke(jti string)
+	IsRevoked(jti string) bool
+}
+
+// inMemoryBlocklist is a process-local tokenBlocklist suitable for a single
+// instance or tests.
+type inMemoryBlocklist struct {
+	mu      sync.RWMutex
+	revoked map[string]struct{}
+}
+
+func newInMemoryBlocklist() *inMemoryBlocklist {
+	return &inMemoryBlocklist{revoked: make(map[string]struct{})}
+}
+
+func (b *inMemoryBlocklist) Revoke(jti string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.revoked[jti] = struct{}{}
+}
+
+func (b *inMemoryBlocklist) IsRevoked(jti string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.revoked[jti]
+	return ok
+}
+
+// jwtSecret returns the signing key from JWT_SECRET, falling back to an
+// insecure development default.
+func jwtSecret() []byte {
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		return []byte(s)
+	}
+	return []byte("dev-secret-do-not-use-in-production")
+}
+
+// jwtExpiry returns the token lifetime from JWT_EXPIRY_MINUTES (default 60).
+func jwtExpiry() time.Duration {
+	if s := os.Getenv("JWT_EXPIRY_MINUTES"); s != "" {
+		if minutes, err := strconv.Atoi(s); err == nil {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return time.Hour
+}
+
+// issueToken signs a JWT for user.
+func issueToken(user User) (string, error) {
+	claims := tokenClaims{
+		UserID:   user.ID,
+		Username: user.Name,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtExpiry())),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret())
+}
+
+// register handles POST /auth/register
+//
+//	@Summary	Register a new user
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		RegisterRequest	true	"Registration details"
+//	@Success	201		{object}	Response
+//	@Failure	400		{object}	Response
+//	@Failure	409		{object}	Response
+//	@Router		/auth/register [post]
+func (s *Server) register(c *gin.Context) {
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error:   "Invalid JSON format or missing fields.",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	newUser := User{Name: req.Name, Email: req.Email, Age: req.Age, Role: "user"}
+	if err := validateUser(newUser); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if _, err := s.store.FindByEmail(c.Request.Context(), req.Email); err == nil {
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Error:   "Email is already registered.",
+			Code:    http.StatusConflict,
+		})
+		return
+	} else if !errors.Is(err, ErrUserNotFound) {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to check existing users.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to hash password.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	newUser.PasswordHash = string(hash)
+
+	created, err := s.store.Create(c.Request.Context(), newUser)
+	if errors.Is(err, ErrEmailTaken) {
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Error:   "Email is already registered.",
+			Code:    http.StatusConflict,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to create user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, Response{
+		Success: true,
+		Message: "User registered successfully.",
+		Data:    created,
+		Code:    http.StatusCreated,
+	})
+}
+
+// login handles POST /auth/login
+//
+//	@Summary	Log in and receive a JWT
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		LoginRequest	true	"Login credentials"
+//	@Success	200		{object}	Response
+//	@Failure	400		{object}	Response
+//	@Failure	401		{object}	Response
+//	@Router		/auth/login [post]
+func (s *Server) login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error:   "Invalid JSON format or missing fields.",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	user, err := s.store.FindByEmail(c.Request.Context(), req.Email)
+	if errors.Is(err, ErrUserNotFound) {
+		c.JSON(http.StatusUnauthorized, Response{
+			Success: false,
+			Error:   "Invalid email or password.",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to look up user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+		c.JSON(http.StatusUnauthorized, Response{
+			Success: false,
+			Error:   "Invalid email or password.",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	token, err := issueToken(*user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to issue token.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    gin.H{"token": token},
+		Code:    http.StatusOK,
+	})
+}
+
+// logout handles POST /auth/logout. It requires a valid token and revokes it
+// by JTI so it can no longer be used even though it hasn't expired yet.
+//
+//	@Summary	Log out and revoke the current token
+//	@Tags		auth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200	{object}	Response
+//	@Failure	401	{object}	Response
+//	@Router		/auth/logout [post]
+func (s *Server) logout(c *gin.Context) {
+	jti, ok := c.Get("tokenID")
+	if !ok {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Missing token claims.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	s.blocklist.Revoke(jti.(string))
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Message: "Logged out successfully.",
+		Code:    http.StatusOK,
+	})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssueTokenRoundTrip(t *testing.T) {
+	user := User{ID: 1, Name: "John Doe", Role: "admin"}
+
+	signed, err := issueToken(user)
+	if err != nil {
+		t.Fatalf("issueToken returned error: %v", err)
+	}
+
+	claims := &tokenClaims{}
+	token, err := jwt.ParseWithClaims(signed, claims, func(t *jwt.Token) (interface{}, error) {
+		return jwtSecret(), nil
+	})
+	if err != nil || !token.Valid {
+		t.Fatalf("ParseWithClaims failed: err=%v valid=%v", err, token.Valid)
+	}
+	if claims.UserID != user.ID || claims.Role != user.Role {
+		t.Fatalf("claims = %+v, want UserID=%d Role=%s", claims, user.ID, user.Role)
+	}
+}
+
+func TestRegisterRejectsDuplicateEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := newServer(newInMemoryStore())
+	router := gin.New()
+	router.POST("/auth/register", server.register)
+
+	body := map[string]interface{}{
+		"name": "Jane Clone", "email": "jane@example.com", "password": "s3cret", "age": 22,
+	}
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("register with taken email status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestInMemoryBlocklist(t *testing.T) {
+	b := newInMemoryBlocklist()
+	if b.IsRevoked("jti-1") {
+		t.Fatalf("IsRevoked(%q) = true before Revoke", "jti-1")
+	}
+	b.Revoke("jti-1")
+	if !b.IsRevoked("jti-1") {
+		t.Fatalf("IsRevoked(%q) = false after Revoke", "jti-1")
+	}
+}
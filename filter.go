@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+// allowedSortFields are the columns getAllUsers/searchUsers may sort by.
+var allowedSortFields = map[string]bool{
+	"id":   true,
+	"name": true,
+	"age":  true,
+}
+
+// UserFilter describes the listing, filtering, sorting, and paging options
+// accepted by GET /users and GET /users/search. Both routes build one of
+// these and hand it to UserStore.List so they share a single filter engine.
+type UserFilter struct {
+	Name        string
+	MinAge      *int
+	MaxAge      *int
+	EmailDomain string
+
+	Sort  string // id, name, or age
+	Order string // asc or desc
+
+	Page  int
+	Limit int
+}
+
+// Pagination describes where a page of results sits within the full result
+// set, returned alongside Data in the paginated response envelope.
+type Pagination struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// PaginatedResponse is the envelope returned by GET /users and
+// GET /users/search.
+type PaginatedResponse struct {
+	Success    bool        `json:"success"`
+	Data       interface{} `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+	Error      string      `json:"error,omitempty"`
+	Code       int         `json:"code,omitempty"`
+}
+
+// newUserFilterFromQuery builds a UserFilter from request query parameters,
+// applying defaults for page/limit/sort/order. It returns an error
+// describing the first invalid parameter it finds.
+func newUserFilterFromQuery(q queryGetter) (UserFilter, error) {
+	filter := UserFilter{
+		Name:        q.Query("name"),
+		EmailDomain: q.Query("email_domain"),
+		Sort:        q.Query("sort"),
+		Order:       q.Query("order"),
+		Page:        defaultPage,
+		Limit:       defaultLimit,
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = "id"
+	} else if !allowedSortFields[filter.Sort] {
+		return UserFilter{}, fmt.Errorf("invalid sort field %q: must be one of id, name, age", filter.Sort)
+	}
+
+	if filter.Order == "" {
+		filter.Order = "asc"
+	} else if filter.Order != "asc" && filter.Order != "desc" {
+		return UserFilter{}, fmt.Errorf("invalid order %q: must be asc or desc", filter.Order)
+	}
+
+	if v := q.Query("page"); v != "" {
+		page, err := parsePositiveInt(v)
+		if err != nil || page < 1 {
+			return UserFilter{}, fmt.Errorf("invalid page %q: must be a positive integer", v)
+		}
+		filter.Page = page
+	}
+
+	if v := q.Query("limit"); v != "" {
+		limit, err := parsePositiveInt(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return UserFilter{}, fmt.Errorf("invalid limit %q: must be between 1 and %d", v, maxLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if v := q.Query("min_age"); v != "" {
+		age, err := parsePositiveInt(v)
+		if err != nil {
+			return UserFilter{}, fmt.Errorf("invalid min_age %q: must be a positive integer", v)
+		}
+		filter.MinAge = &age
+	}
+
+	if v := q.Query("max_age"); v != "" {
+		age, err := parsePositiveInt(v)
+		if err != nil {
+			return UserFilter{}, fmt.Errorf("invalid max_age %q: must be a positive integer", v)
+		}
+		filter.MaxAge = &age
+	}
+
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		return UserFilter{}, fmt.Errorf("min_age %d cannot be greater than max_age %d", *filter.MinAge, *filter.MaxAge)
+	}
+
+	return filter, nil
+}
+
+// queryGetter is satisfied by *gin.Context; it's factored out so the filter
+// parsing logic can be unit tested without spinning up gin.
+type queryGetter interface {
+	Query(key string) string
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("%d is not a positive integer", n)
+	}
+	return n, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInMemoryStoreListPaginatesAndSorts(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore()
+
+	page, total, err := store.List(ctx, UserFilter{Sort: "name", Order: "asc", Page: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("total = %d, want 3", total)
+	}
+	if len(page) != 2 || page[0].Name != "Bob Wilson" || page[1].Name != "Jane Smith" {
+		t.Fatalf("page 1 sorted by name asc = %+v, want [Bob Wilson, Jane Smith]", page)
+	}
+
+	page2, _, err := store.List(ctx, UserFilter{Sort: "name", Order: "asc", Page: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("List page 2 returned error: %v", err)
+	}
+	if len(page2) != 1 || page2[0].Name != "John Doe" {
+		t.Fatalf("page 2 sorted by name asc = %+v, want [John Doe]", page2)
+	}
+}
+
+type fakeQuery map[string]string
+
+func (f fakeQuery) Query(key string) string { return f[key] }
+
+func TestNewUserFilterFromQueryRejectsInvalidSort(t *testing.T) {
+	_, err := newUserFilterFromQuery(fakeQuery{"sort": "bogus"})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid sort field")
+	}
+}
+
+func TestNewUserFilterFromQueryRejectsOutOfRangeLimit(t *testing.T) {
+	_, err := newUserFilterFromQuery(fakeQuery{"limit": "1000"})
+	if err == nil {
+		t.Fatalf("expected an error for a limit above %d", maxLimit)
+	}
+}
+
+func TestNewUserFilterFromQueryDefaults(t *testing.T) {
+	filter, err := newUserFilterFromQuery(fakeQuery{})
+	if err != nil {
+		t.Fatalf("newUserFilterFromQuery returned error: %v", err)
+	}
+	if filter.Page != defaultPage || filter.Limit != defaultLimit || filter.Sort != "id" || filter.Order != "asc" {
+		t.Fatalf("defaults = %+v, want page=%d limit=%d sort=id order=asc", filter, defaultPage, defaultLimit)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds how long a readiness check waits on the store's
+// Ping before reporting not-ready.
+const readinessTimeout = 2 * time.Second
+
+// healthz handles GET /healthz. It reports liveness: a 200 means the process
+// is up and serving, independent of any dependency's health.
+//
+//	@Summary	Liveness probe
+//	@Tags		health
+//	@Produce	json
+//	@Success	200	{object}	Response
+//	@Router		/healthz [get]
+func (s *Server) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{Success: true, Message: "ok", Code: http.StatusOK})
+}
+
+// readyz handles GET /readyz. It reports readiness: a 503 means the store
+// isn't reachable yet, so a load balancer should hold off routing traffic
+// here (e.g. during startup or a rolling restart).
+//
+//	@Summary	Readiness probe
+//	@Tags		health
+//	@Produce	json
+//	@Success	200	{object}	Response
+//	@Failure	503	{object}	Response
+//	@Router		/readyz [get]
+func (s *Server) readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := s.store.Ping(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, Response{
+			Success: false,
+			Error:   "Store is not reachable.",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Message: "ok", Code: http.StatusOK})
+}
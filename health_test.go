@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHealthzAlwaysOK(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := newServer(newInMemoryStore())
+
+	router := gin.New()
+	router.GET("/healthz", server.healthz)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("healthz status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+type unreadyStore struct{ UserStore }
+
+func (unreadyStore) Ping(ctx context.Context) error { return errors.New("db down") }
+
+func TestReadyzReflectsStorePing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := newServer(newInMemoryStore())
+
+	router := gin.New()
+	router.GET("/readyz", server.readyz)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("readyz status = %d, want %d when store is healthy", rec.Code, http.StatusOK)
+	}
+
+	server.store = unreadyStore{}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz status = %d, want %d when store ping fails", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -1,21 +1,52 @@
 // Build a simple User Management API using Gin with basic HTTP routing and request handling.
+//
+//	@title			gin-crud-api
+//	@version		1.0
+//	@description	A small User Management API built with Gin.
+//	@BasePath		/
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
 package main
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	_ "github.com/Harschmann/gin-crud-api/docs"
 )
 
 // User represents a user in our system
 type User struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Age          int    `json:"age"`
+	PasswordHash string `json:"-"`
+	Role         string `json:"role"`
+}
+
+// UpdateUserRequest is the body accepted by PUT /users/:id. It deliberately
+// excludes PasswordHash, and Role is only honored for callers with the
+// admin role so a user can't escalate their own privileges via a self update.
+type UpdateUserRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required"`
 	Age   int    `json:"age"`
+	Role  string `json:"role,omitempty"`
 }
 
 // Response represents a standard API response
@@ -27,40 +58,185 @@ type Response struct {
 	Code    int         `json:"code,omitempty"`
 }
 
-// In-memory storage
-var users = []User{
-	{ID: 1, Name: "John Doe", Email: "john@example.com", Age: 30},
-	{ID: 2, Name: "Jane Smith", Email: "jane@example.com", Age: 25},
-	{ID: 3, Name: "Bob Wilson", Email: "bob@example.com", Age: 35},
+// Server holds the dependencies shared by the HTTP handlers. Using a store
+// interface (rather than package-level globals) keeps request handling safe
+// under concurrent access and lets us swap storage backends in tests.
+type Server struct {
+	store     UserStore
+	blocklist tokenBlocklist
+}
+
+// newServer wires up a Server backed by store.
+func newServer(store UserStore) *Server {
+	return &Server{store: store, blocklist: newInMemoryBlocklist()}
 }
-var nextID = 4
 
 func main() {
-	router := gin.Default()
+	server := newServer(newStoreFromEnv())
+
+	router := gin.New()
+	router.Use(requestID(), structuredLogger(), metrics(), gin.Recovery())
+
+	router.GET("/metrics", metricsHandler())
+	router.GET("/healthz", server.healthz)
+	router.GET("/readyz", server.readyz)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	router.POST("/auth/register", server.register)
+	router.POST("/auth/login", server.login)
+	router.POST("/auth/logout", server.AuthRequired(), server.logout)
+
+	router.GET("/users", server.getAllUsers)
+	router.GET("/users/:id", server.getUserByID)
+	router.GET("/users/search", server.searchUsers)
+	router.POST("/users", server.AuthRequired(), server.RequireRole("admin"), server.createUser)
+	router.PUT("/users/:id", server.AuthRequired(), server.updateUser)
+	router.DELETE("/users/:id", server.AuthRequired(), server.deleteUser)
+
+	srv := &http.Server{
+		Addr:         ":8080",
+		Handler:      router,
+		ReadTimeout:  time.Duration(envInt("HTTP_READ_TIMEOUT_SECONDS", 10)) * time.Second,
+		WriteTimeout: time.Duration(envInt("HTTP_WRITE_TIMEOUT_SECONDS", 10)) * time.Second,
+		IdleTimeout:  time.Duration(envInt("HTTP_IDLE_TIMEOUT_SECONDS", 120)) * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal().Err(err).Msg("server failed")
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Info().Msg("shutting down")
 
-	router.GET("/users", getAllUsers)
-	router.GET("/users/:id", getUserByID)
-	router.POST("/users", createUser)
-	router.PUT("/users/:id", updateUser)
-	router.DELETE("/users/:id", deleteUser)
-	router.GET("/users/search", searchUsers)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(envInt("SHUTDOWN_GRACE_SECONDS", 15))*time.Second)
+	defer cancel()
 
-	router.Run(":8080")
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("graceful shutdown failed")
+	}
 }
 
-// getAllUsers handles GET /users
-func getAllUsers(c *gin.Context) {
-	c.JSON(http.StatusOK, Response{
+// newStoreFromEnv returns a sqlStore when DATABASE_URL is configured,
+// otherwise falls back to an inMemoryStore so the API still runs out of the
+// box for local development and tests.
+func newStoreFromEnv() UserStore {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return newInMemoryStore()
+	}
+
+	driver := os.Getenv("DATABASE_DRIVER")
+	if driver == "" {
+		driver = "postgres"
+	}
+
+	store, err := newSQLStore(driver, dsn)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+// getAllUsers handles GET /users?page=&limit=&sort=&order=&min_age=&max_age=&email_domain=&name=
+//
+//	@Summary	List users
+//	@Tags		users
+//	@Produce	json
+//	@Param		page			query		int		false	"Page number"			default(1)
+//	@Param		limit			query		int		false	"Page size (max 100)"	default(10)
+//	@Param		sort			query		string	false	"Sort field: id, name, or age"
+//	@Param		order			query		string	false	"Sort order: asc or desc"
+//	@Param		name			query		string	false	"Fuzzy match on name"
+//	@Param		min_age			query		int		false	"Minimum age"
+//	@Param		max_age			query		int		false	"Maximum age"
+//	@Param		email_domain	query		string	false	"Exact email domain match"
+//	@Success	200				{object}	PaginatedResponse
+//	@Failure	400				{object}	Response
+//	@Router		/users [get]
+func (s *Server) getAllUsers(c *gin.Context) {
+	s.listUsers(c)
+}
+
+// searchUsers handles GET /users/search?name=value, delegating to the same
+// filter engine as getAllUsers so clients can list, search, and page
+// through one consistent interface.
+//
+//	@Summary	Search users by name
+//	@Tags		users
+//	@Produce	json
+//	@Param		name	query		string	true	"Fuzzy match on name"
+//	@Param		page	query		int		false	"Page number"			default(1)
+//	@Param		limit	query		int		false	"Page size (max 100)"	default(10)
+//	@Success	200		{object}	PaginatedResponse
+//	@Failure	400		{object}	Response
+//	@Router		/users/search [get]
+func (s *Server) searchUsers(c *gin.Context) {
+	if c.Query("name") == "" {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error:   "Missing 'name' query parameter for search.",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	s.listUsers(c)
+}
+
+// listUsers parses a UserFilter from the request's query parameters,
+// fetches the matching page from the store, and writes a paginated
+// response envelope.
+func (s *Server) listUsers(c *gin.Context) {
+	filter, err := newUserFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	users, total, err := s.store.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to fetch users.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	totalPages := (total + filter.Limit - 1) / filter.Limit
+	c.JSON(http.StatusOK, PaginatedResponse{
 		Success: true,
 		Data:    users,
-		Code:    http.StatusOK,
+		Pagination: Pagination{
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
 	})
 }
 
 // getUserByID handles GET /users/:id
-func getUserByID(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+//
+//	@Summary	Get a user by ID
+//	@Tags		users
+//	@Produce	json
+//	@Param		id	path		int	true	"User ID"
+//	@Success	200	{object}	Response
+//	@Failure	400	{object}	Response
+//	@Failure	404	{object}	Response
+//	@Router		/users/{id} [get]
+func (s *Server) getUserByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Success: false,
@@ -69,15 +245,24 @@ func getUserByID(c *gin.Context) {
 		})
 		return
 	}
-	user, _ := findUserByID(id)
-	if user == nil {
+
+	user, err := s.store.Get(c.Request.Context(), id)
+	if errors.Is(err, ErrUserNotFound) {
 		c.JSON(http.StatusNotFound, Response{
 			Success: false,
 			Error:   "User not found.",
 			Code:    http.StatusNotFound,
 		})
 		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to fetch user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
 	}
+
 	c.JSON(http.StatusOK, Response{
 		Success: true,
 		Data:    user,
@@ -86,7 +271,19 @@ func getUserByID(c *gin.Context) {
 }
 
 // createUser handles POST /users
-func createUser(c *gin.Context) {
+//
+//	@Summary	Create a user
+//	@Tags		users
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		user	body		User	true	"User to create"
+//	@Success	201		{object}	Response
+//	@Failure	400		{object}	Response
+//	@Failure	401		{object}	Response
+//	@Failure	403		{object}	Response
+//	@Router		/users [post]
+func (s *Server) createUser(c *gin.Context) {
 	var newUser User
 	if err := c.ShouldBindJSON(&newUser); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
@@ -105,20 +302,40 @@ func createUser(c *gin.Context) {
 		return
 	}
 
-	newUser.ID = nextID
-	users = append(users, newUser)
-	nextID++
+	created, err := s.store.Create(c.Request.Context(), newUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to create user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
 
 	c.JSON(http.StatusCreated, Response{
 		Success: true,
 		Message: "User created successfully.",
-		Data:    newUser,
+		Data:    created,
 		Code:    http.StatusCreated,
 	})
 }
 
 // updateUser handles PUT /users/:id
-func updateUser(c *gin.Context) {
+//
+//	@Summary	Update a user
+//	@Tags		users
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id		path		int					true	"User ID"
+//	@Param		user	body		UpdateUserRequest	true	"Updated user"
+//	@Success	200		{object}	Response
+//	@Failure	400		{object}	Response
+//	@Failure	401		{object}	Response
+//	@Failure	403		{object}	Response
+//	@Failure	404		{object}	Response
+//	@Router		/users/{id} [put]
+func (s *Server) updateUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
@@ -129,18 +346,17 @@ func updateUser(c *gin.Context) {
 		return
 	}
 
-	existingUser, idx := findUserByID(id)
-	if existingUser == nil {
-		c.JSON(http.StatusNotFound, Response{
+	if !callerMayModify(c, id) {
+		c.JSON(http.StatusForbidden, Response{
 			Success: false,
-			Error:   "User not found.",
-			Code:    http.StatusNotFound,
+			Error:   "You may only modify your own record.",
+			Code:    http.StatusForbidden,
 		})
 		return
 	}
 
-	var updatedData User
-	if err := c.ShouldBindJSON(&updatedData); err != nil {
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Success: false,
 			Error:   "Invalid JSON format.",
@@ -149,6 +365,44 @@ func updateUser(c *gin.Context) {
 		return
 	}
 
+	existing, err := s.store.Get(c.Request.Context(), id)
+	if errors.Is(err, ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error:   "User not found.",
+			Code:    http.StatusNotFound,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to update user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if req.Role != "" && req.Role != existing.Role {
+		caller, _ := c.Get("currentUser")
+		if caller.(currentUser).Role != "admin" {
+			c.JSON(http.StatusForbidden, Response{
+				Success: false,
+				Error:   "Only admins may change role.",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+		existing.Role = req.Role
+	}
+
+	updatedData := User{
+		Name:         req.Name,
+		Email:        req.Email,
+		Age:          req.Age,
+		PasswordHash: existing.PasswordHash,
+		Role:         existing.Role,
+	}
+
 	if err := validateUser(updatedData); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Success: false,
@@ -158,18 +412,45 @@ func updateUser(c *gin.Context) {
 		return
 	}
 
-	updatedData.ID = id
-	users[idx] = updatedData
+	updated, err := s.store.Update(c.Request.Context(), id, updatedData)
+	if errors.Is(err, ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error:   "User not found.",
+			Code:    http.StatusNotFound,
+		})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error:   "Failed to update user.",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		Success: true,
 		Message: "User updated successfully.",
-		Data:    users[idx],
+		Data:    updated,
 		Code:    http.StatusOK,
 	})
 }
 
 // deleteUser handles DELETE /users/:id
-func deleteUser(c *gin.Context) {
+//
+//	@Summary	Delete a user
+//	@Tags		users
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id	path		int	true	"User ID"
+//	@Success	200	{object}	Response
+//	@Failure	400	{object}	Response
+//	@Failure	401	{object}	Response
+//	@Failure	403	{object}	Response
+//	@Failure	404	{object}	Response
+//	@Router		/users/{id} [delete]
+func (s *Server) deleteUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
@@ -180,61 +461,39 @@ func deleteUser(c *gin.Context) {
 		return
 	}
 
-	user, idx := findUserByID(id)
-	if user == nil {
+	if !callerMayModify(c, id) {
+		c.JSON(http.StatusForbidden, Response{
+			Success: false,
+			Error:   "You may only delete your own record.",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	err = s.store.Delete(c.Request.Context(), id)
+	if errors.Is(err, ErrUserNotFound) {
 		c.JSON(http.StatusNotFound, Response{
 			Success: false,
 			Error:   "User not found.",
 			Code:    http.StatusNotFound,
 		})
 		return
-	}
-
-	users = append(users[:idx], users[idx+1:]...)
-	c.JSON(http.StatusOK, Response{
-		Success: true,
-		Message: "User deleted successfully.",
-		Code:    http.StatusOK,
-	})
-}
-
-// searchUsers handles GET /users/search?name=value
-func searchUsers(c *gin.Context) {
-	searchName := c.Query("name")
-	if searchName == "" {
-		c.JSON(http.StatusBadRequest, Response{
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
 			Success: false,
-			Error:   "Missing 'name' query parameter for search.",
-			Code:    http.StatusBadRequest,
+			Error:   "Failed to delete user.",
+			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
 
-	var matchingUsers []User
-	searchNameLower := strings.ToLower(searchName)
-	for _, user := range users {
-		if strings.Contains(strings.ToLower(user.Name), searchNameLower) {
-			matchingUsers = append(matchingUsers, user)
-		}
-	}
-
 	c.JSON(http.StatusOK, Response{
 		Success: true,
-		Data:    matchingUsers,
+		Message: "User deleted successfully.",
 		Code:    http.StatusOK,
 	})
 }
 
-// Helper function to find user by ID
-func findUserByID(id int) (*User, int) {
-	for i, u := range users {
-		if u.ID == id {
-			return &users[i], i
-		}
-	}
-	return nil, -1
-}
-
 // Helper function to validate user data
 func validateUser(user User) error {
 	if strings.TrimSpace(user.Name) == "" {
@@ -250,4 +509,4 @@ func validateUser(user User) error {
 		return errors.New("age must be a positive integer (1-150)")
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter wires a PUT /users/:id route with a gin context pre-seeded
+// as the given caller, bypassing AuthRequired so tests can focus on
+// updateUser's own authorization logic.
+func newTestRouter(server *Server, caller currentUser) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/users/:id", func(c *gin.Context) {
+		c.Set("currentUser", caller)
+		server.updateUser(c)
+	})
+	return router
+}
+
+func putUser(router *gin.Engine, id string, body map[string]interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, "/users/"+id, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUpdateUserRejectsSelfRoleEscalation(t *testing.T) {
+	store := newInMemoryStore()
+	created, err := store.Create(context.Background(), User{Name: "Jane Doe", Email: "jane.doe@example.com", Age: 25, Role: "user"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	server := newServer(store)
+	router := newTestRouter(server, currentUser{ID: created.ID, Username: "jane", Role: "user"})
+
+	rec := putUser(router, strconv.Itoa(created.ID), map[string]interface{}{
+		"name": "Jane Doe", "email": "jane.doe@example.com", "age": 25, "role": "admin",
+	})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d for self role escalation", rec.Code, http.StatusForbidden)
+	}
+
+	got, err := store.Get(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Role != "user" {
+		t.Fatalf("Role = %q after rejected escalation attempt, want %q", got.Role, "user")
+	}
+}
+
+func TestUpdateUserAllowsAdminToChangeRole(t *testing.T) {
+	store := newInMemoryStore()
+	created, err := store.Create(context.Background(), User{Name: "Jane Doe", Email: "jane.doe@example.com", Age: 25, Role: "user"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	server := newServer(store)
+	router := newTestRouter(server, currentUser{ID: 999, Username: "root", Role: "admin"})
+
+	id := strconv.Itoa(created.ID)
+	rec := putUser(router, id, map[string]interface{}{
+		"name": "Jane Doe", "email": "jane.doe@example.com", "age": 25, "role": "admin",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d for admin-driven role change", rec.Code, http.StatusOK)
+	}
+
+	got, err := store.Get(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Role != "admin" {
+		t.Fatalf("Role = %q after admin update, want %q", got.Role, "admin")
+	}
+}
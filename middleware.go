@@ -0,0 +1,90 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// currentUser is the caller identity stashed in the gin context by
+// AuthRequired, available to handlers via c.Get("currentUser").
+type currentUser struct {
+	ID       int
+	Username string
+	Role     string
+}
+
+// AuthRequired validates the Authorization: Bearer <token> header, rejecting
+// the request if the token is missing, malformed, expired, or revoked. On
+// success it stashes a currentUser and the token's JTI into the context.
+func (s *Server) AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, Response{
+				Success: false,
+				Error:   "Missing or malformed Authorization header.",
+				Code:    http.StatusUnauthorized,
+			})
+			return
+		}
+
+		claims := &tokenClaims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
+			return jwtSecret(), nil
+		})
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, Response{
+				Success: false,
+				Error:   "Invalid or expired token.",
+				Code:    http.StatusUnauthorized,
+			})
+			return
+		}
+
+		if s.blocklist.IsRevoked(claims.ID) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, Response{
+				Success: false,
+				Error:   "Token has been revoked.",
+				Code:    http.StatusUnauthorized,
+			})
+			return
+		}
+
+		c.Set("currentUser", currentUser{ID: claims.UserID, Username: claims.Username, Role: claims.Role})
+		c.Set("tokenID", claims.ID)
+		c.Next()
+	}
+}
+
+// callerMayModify reports whether the authenticated caller is allowed to
+// modify the user identified by id: either they are that user, or they hold
+// the admin role.
+func callerMayModify(c *gin.Context, id int) bool {
+	user, ok := c.Get("currentUser")
+	if !ok {
+		return false
+	}
+	caller := user.(currentUser)
+	return caller.ID == id || caller.Role == "admin"
+}
+
+// RequireRole aborts the request with 403 unless the authenticated caller
+// has the given role. It must run after AuthRequired.
+func (s *Server) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get("currentUser")
+		if !ok || user.(currentUser).Role != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, Response{
+				Success: false,
+				Error:   "Insufficient permissions.",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+		c.Next()
+	}
+}
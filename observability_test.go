@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDGeneratedWhenAbsent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestID())
+	router.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, requestIDFrom(c))
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(rec, req)
+
+	id := rec.Header().Get(requestIDHeader)
+	if id == "" {
+		t.Fatal("expected a generated X-Request-ID header")
+	}
+	if rec.Body.String() != id {
+		t.Fatalf("requestIDFrom returned %q, want %q", rec.Body.String(), id)
+	}
+}
+
+func TestRequestIDReusesClientValue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestID())
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(requestIDHeader, "client-supplied-id")
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(requestIDHeader); got != "client-supplied-id" {
+		t.Fatalf("X-Request-ID = %q, want %q", got, "client-supplied-id")
+	}
+}
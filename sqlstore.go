@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// sqlStore is a UserStore backed by a SQL database via database/sql. It
+// targets Postgres specifically (github.com/lib/pq): queries use Postgres's
+// $n placeholder syntax and ILIKE, so pointing it at another driver will
+// fail on the first query rather than just working.
+type sqlStore struct {
+	db *sql.DB
+}
+
+// newSQLStore opens a connection pool to dataSourceName using the given
+// driver (must be "postgres") and configures pooling from env vars:
+//
+//	DB_MAX_OPEN_CONNS (default 25)
+//	DB_MAX_IDLE_CONNS (default 25)
+//	DB_CONN_MAX_LIFETIME_MINUTES (default 5)
+func newSQLStore(driverName, dataSourceName string) (*sqlStore, error) {
+	if driverName != "postgres" {
+		return nil, fmt.Errorf("unsupported driver %q: sqlStore only supports postgres", driverName)
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+
+	db.SetMaxOpenConns(envInt("DB_MAX_OPEN_CONNS", 25))
+	db.SetMaxIdleConns(envInt("DB_MAX_IDLE_CONNS", 25))
+	db.SetConnMaxLifetime(time.Duration(envInt("DB_CONN_MAX_LIFETIME_MINUTES", 5)) * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	return &sqlStore{db: db}, nil
+}
+
+// Ping reports whether the underlying database connection is healthy.
+func (s *sqlStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *sqlStore) List(ctx context.Context, filter UserFilter) ([]User, int, error) {
+	where, args := filter.whereClause()
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM users" + where
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count users: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id, name, email, age, password_hash, role FROM users%s ORDER BY %s %s LIMIT %d OFFSET %d",
+		where, sqlSortColumn(filter.Sort), sqlOrderDirection(filter.Order), filter.Limit, (filter.Page-1)*filter.Limit,
+	)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	users, err := scanUsers(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// whereClause builds a parameterized SQL WHERE clause (or "" if filter has
+// no predicates) from the non-pagination fields of filter.
+func (f UserFilter) whereClause() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if f.Name != "" {
+		args = append(args, "%"+f.Name+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if f.MinAge != nil {
+		args = append(args, *f.MinAge)
+		conditions = append(conditions, fmt.Sprintf("age >= $%d", len(args)))
+	}
+	if f.MaxAge != nil {
+		args = append(args, *f.MaxAge)
+		conditions = append(conditions, fmt.Sprintf("age <= $%d", len(args)))
+	}
+	if f.EmailDomain != "" {
+		args = append(args, "%@"+f.EmailDomain)
+		conditions = append(conditions, fmt.Sprintf("email ILIKE $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// sqlSortColumn maps a validated UserFilter.Sort value to its column name.
+// "id" and any unrecognized value map to the id column, so the column name
+// written into the query is always one of id, name, or age.
+func sqlSortColumn(sort string) string {
+	switch sort {
+	case "name", "age":
+		return sort
+	default:
+		return "id"
+	}
+}
+
+func sqlOrderDirection(order string) string {
+	if order == "desc" {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+func (s *sqlStore) Get(ctx context.Context, id int) (*User, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT id, name, email, age, password_hash, role FROM users WHERE id = $1`, id)
+
+	var u User
+	if err := row.Scan(&u.ID, &u.Name, &u.Email, &u.Age, &u.PasswordHash, &u.Role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return &u, nil
+}
+
+func (s *sqlStore) FindByEmail(ctx context.Context, email string) (*User, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, name, email, age, password_hash, role FROM users WHERE email = $1`, email)
+
+	var u User
+	if err := row.Scan(&u.ID, &u.Name, &u.Email, &u.Age, &u.PasswordHash, &u.Role); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user by email: %w", err)
+	}
+	return &u, nil
+}
+
+func (s *sqlStore) Create(ctx context.Context, user User) (User, error) {
+	if user.Role == "" {
+		user.Role = "user"
+	}
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO users (name, email, age, password_hash, role) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		user.Name, user.Email, user.Age, user.PasswordHash, user.Role,
+	).Scan(&user.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+			return User{}, ErrEmailTaken
+		}
+		return User{}, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *sqlStore) Update(ctx context.Context, id int, user User) (User, error) {
+	user.ID = id
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET name = $1, email = $2, age = $3, password_hash = $4, role = $5 WHERE id = $6`,
+		user.Name, user.Email, user.Age, user.PasswordHash, user.Role, id,
+	)
+	if err != nil {
+		return User{}, fmt.Errorf("update user: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return User{}, fmt.Errorf("update user: %w", err)
+	} else if n == 0 {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *sqlStore) Delete(ctx context.Context, id int) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func scanUsers(rows *sql.Rows) ([]User, error) {
+	var out []User
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Age, &u.PasswordHash, &u.Role); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// envInt reads an int env var, falling back to def if unset or invalid.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func newMockSQLStore(t *testing.T) (*sqlStore, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock.New() returned error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &sqlStore{db: db}, mock
+}
+
+func TestNewSQLStoreRejectsNonPostgresDriver(t *testing.T) {
+	if _, err := newSQLStore("mysql", "dsn"); err == nil {
+		t.Fatal("newSQLStore(\"mysql\", ...) returned nil error, want a rejection")
+	}
+}
+
+func TestSQLStoreGet(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "age", "password_hash", "role"}).
+		AddRow(1, "John Doe", "john@example.com", 30, "hash", "admin")
+	mock.ExpectQuery(`SELECT id, name, email, age, password_hash, role FROM users WHERE id = \$1`).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	user, err := store.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if user.Name != "John Doe" || user.Role != "admin" {
+		t.Fatalf("Get = %+v, want Name=John Doe Role=admin", user)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSQLStoreGetNotFound(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+
+	mock.ExpectQuery(`SELECT id, name, email, age, password_hash, role FROM users WHERE id = \$1`).
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "age", "password_hash", "role"}))
+
+	if _, err := store.Get(context.Background(), 404); err != ErrUserNotFound {
+		t.Fatalf("Get returned err=%v, want ErrUserNotFound", err)
+	}
+}
+
+func TestSQLStoreUpdatePersistsAllMutableColumns(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+
+	mock.ExpectExec(`UPDATE users SET name = \$1, email = \$2, age = \$3, password_hash = \$4, role = \$5 WHERE id = \$6`).
+		WithArgs("Jane", "jane@example.com", 26, "newhash", "admin", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	updated, err := store.Update(context.Background(), 1, User{
+		Name: "Jane", Email: "jane@example.com", Age: 26, PasswordHash: "newhash", Role: "admin",
+	})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if updated.Role != "admin" || updated.PasswordHash != "newhash" {
+		t.Fatalf("Update = %+v, want Role=admin PasswordHash=newhash", updated)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSQLStoreUpdateNotFound(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+
+	mock.ExpectExec(`UPDATE users SET name = \$1, email = \$2, age = \$3, password_hash = \$4, role = \$5 WHERE id = \$6`).
+		WithArgs("Jane", "jane@example.com", 26, "newhash", "admin", 404).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := store.Update(context.Background(), 404, User{
+		Name: "Jane", Email: "jane@example.com", Age: 26, PasswordHash: "newhash", Role: "admin",
+	}); err != ErrUserNotFound {
+		t.Fatalf("Update returned err=%v, want ErrUserNotFound", err)
+	}
+}
+
+func TestSQLStoreCreateRejectsDuplicateEmail(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+
+	mock.ExpectQuery(`INSERT INTO users \(name, email, age, password_hash, role\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id`).
+		WithArgs("Jane", "jane@example.com", 26, "hash", "user").
+		WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+
+	if _, err := store.Create(context.Background(), User{
+		Name: "Jane", Email: "jane@example.com", Age: 26, PasswordHash: "hash", Role: "user",
+	}); !errors.Is(err, ErrEmailTaken) {
+		t.Fatalf("Create with duplicate email returned err=%v, want ErrEmailTaken", err)
+	}
+}
+
+func TestSQLStorePing(t *testing.T) {
+	store, mock := newMockSQLStore(t)
+	mock.ExpectPing()
+
+	if err := store.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping returned error: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUserNotFound is returned by a UserStore when no user matches the given
+// ID (or, for FindByEmail, the given email).
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrEmailTaken is returned by UserStore.Create when another user already
+// has the given email.
+var ErrEmailTaken = errors.New("email already registered")
+
+// UserStore abstracts persistence for users so handlers don't depend on a
+// concrete storage technology. inMemoryStore is used by default and in
+// tests; sqlStore backs real deployments.
+type UserStore interface {
+	// List returns the page of users matching filter along with the total
+	// number of matches (before paging), so callers can build a Pagination.
+	List(ctx context.Context, filter UserFilter) (users []User, total int, err error)
+	Get(ctx context.Context, id int) (*User, error)
+	Create(ctx context.Context, user User) (User, error)
+	Update(ctx context.Context, id int, user User) (User, error)
+	Delete(ctx context.Context, id int) error
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	// Ping reports whether the store is reachable, so callers can expose
+	// readiness to orchestrators like Kubernetes.
+	Ping(ctx context.Context) error
+}
+
+// inMemoryStore is a UserStore backed by a mutex-guarded slice. It preserves
+// the original in-memory behavior of this API and is safe for concurrent
+// requests.
+type inMemoryStore struct {
+	mu     sync.RWMutex
+	users  []User
+	nextID int
+}
+
+// newInMemoryStore returns an inMemoryStore seeded with the same sample data
+// the API has always shipped with.
+func newInMemoryStore() *inMemoryStore {
+	return &inMemoryStore{
+		users: []User{
+			{ID: 1, Name: "John Doe", Email: "john@example.com", Age: 30, Role: "admin"},
+			{ID: 2, Name: "Jane Smith", Email: "jane@example.com", Age: 25, Role: "user"},
+			{ID: 3, Name: "Bob Wilson", Email: "bob@example.com", Age: 35, Role: "user"},
+		},
+		nextID: 4,
+	}
+}
+
+func (s *inMemoryStore) List(ctx context.Context, filter UserFilter) ([]User, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]User, 0, len(s.users))
+	for _, u := range s.users {
+		if matchesFilter(u, filter) {
+			matches = append(matches, u)
+		}
+	}
+
+	sortUsers(matches, filter.Sort, filter.Order)
+
+	total := len(matches)
+	start := (filter.Page - 1) * filter.Limit
+	if start >= total {
+		return []User{}, total, nil
+	}
+	end := start + filter.Limit
+	if end > total {
+		end = total
+	}
+
+	page := make([]User, end-start)
+	copy(page, matches[start:end])
+	return page, total, nil
+}
+
+// matchesFilter reports whether u satisfies every predicate set on filter.
+func matchesFilter(u User, filter UserFilter) bool {
+	if filter.Name != "" && !strings.Contains(strings.ToLower(u.Name), strings.ToLower(filter.Name)) {
+		return false
+	}
+	if filter.MinAge != nil && u.Age < *filter.MinAge {
+		return false
+	}
+	if filter.MaxAge != nil && u.Age > *filter.MaxAge {
+		return false
+	}
+	if filter.EmailDomain != "" {
+		_, domain, ok := strings.Cut(u.Email, "@")
+		if !ok || !strings.EqualFold(domain, filter.EmailDomain) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortUsers sorts users in place by field ("id", "name", or "age") in the
+// given order ("asc" or "desc").
+func sortUsers(users []User, field, order string) {
+	less := func(i, j int) bool {
+		switch field {
+		case "name":
+			return users[i].Name < users[j].Name
+		case "age":
+			return users[i].Age < users[j].Age
+		default:
+			return users[i].ID < users[j].ID
+		}
+	}
+	if order == "desc" {
+		base := less
+		less = func(i, j int) bool { return base(j, i) }
+	}
+	sort.SliceStable(users, less)
+}
+
+func (s *inMemoryStore) Get(ctx context.Context, id int) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.ID == id {
+			user := u
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (s *inMemoryStore) Create(ctx context.Context, user User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if strings.EqualFold(u.Email, user.Email) {
+			return User{}, ErrEmailTaken
+		}
+	}
+
+	user.ID = s.nextID
+	s.nextID++
+	s.users = append(s.users, user)
+	return user, nil
+}
+
+func (s *inMemoryStore) Update(ctx context.Context, id int, user User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, u := range s.users {
+		if u.ID == id {
+			user.ID = id
+			s.users[i] = user
+			return user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (s *inMemoryStore) Delete(ctx context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, u := range s.users {
+		if u.ID == id {
+			s.users = append(s.users[:i], s.users[i+1:]...)
+			return nil
+		}
+	}
+	return ErrUserNotFound
+}
+
+func (s *inMemoryStore) FindByEmail(ctx context.Context, email string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if strings.EqualFold(u.Email, email) {
+			user := u
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// Ping always succeeds: an inMemoryStore has no external dependency to check.
+func (s *inMemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestInMemoryStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore()
+
+	created, err := store.Create(ctx, User{Name: "Alice", Email: "alice@example.com", Age: 40})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatalf("Create did not assign an ID")
+	}
+
+	got, err := store.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Name != "Alice" {
+		t.Fatalf("Get returned %+v, want Name=Alice", got)
+	}
+
+	updated, err := store.Update(ctx, created.ID, User{Name: "Alice B", Email: "alice@example.com", Age: 41})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if updated.Name != "Alice B" {
+		t.Fatalf("Update returned %+v, want Name=Alice B", updated)
+	}
+
+	if err := store.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	if _, err := store.Get(ctx, created.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("Get after Delete returned err=%v, want ErrUserNotFound", err)
+	}
+}
+
+func TestInMemoryStoreListFiltersByName(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore()
+
+	matches, total, err := store.List(ctx, UserFilter{Name: "jane", Sort: "id", Order: "asc", Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if total != 1 || len(matches) != 1 || matches[0].Name != "Jane Smith" {
+		t.Fatalf("List(Name=jane) = %+v (total %d), want one match for Jane Smith", matches, total)
+	}
+}
+
+func TestInMemoryStoreCreateRejectsDuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore()
+
+	if _, err := store.Create(ctx, User{Name: "Jane Clone", Email: "jane@example.com", Age: 22}); !errors.Is(err, ErrEmailTaken) {
+		t.Fatalf("Create with existing email returned err=%v, want ErrEmailTaken", err)
+	}
+}
+
+func TestInMemoryStoreUpdateNotFound(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryStore()
+
+	if _, err := store.Update(ctx, 999, User{Name: "Nobody", Email: "nobody@example.com", Age: 20}); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("Update on missing user returned err=%v, want ErrUserNotFound", err)
+	}
+}